@@ -0,0 +1,292 @@
+package ligno
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// policyKind distinguishes the behaviors a Policy selects when the async
+// handler's buffer is full.
+type policyKind int
+
+const (
+	policyBlock policyKind = iota
+	policyDropOldest
+	policyDropNewest
+	policySampleTail
+)
+
+// Policy selects what AsyncHandler does with a record that arrives while its
+// buffer is full.
+type Policy struct {
+	kind policyKind
+	rate float64
+}
+
+var (
+	// BlockOnFull makes Handle block until the buffer has room, applying
+	// backpressure to the caller instead of losing records.
+	BlockOnFull = Policy{kind: policyBlock}
+
+	// DropOldest evicts the oldest buffered record to make room for the new
+	// one.
+	DropOldest = Policy{kind: policyDropOldest}
+
+	// DropNewest discards the incoming record when the buffer is full,
+	// leaving already-buffered records untouched.
+	DropNewest = Policy{kind: policyDropNewest}
+)
+
+// SampleTail returns a Policy that, once the buffer is full, only admits a
+// fraction rate (0 to 1) of incoming records, evicting the oldest buffered
+// record to make room for the ones it admits.
+func SampleTail(rate float64) Policy {
+	return Policy{kind: policySampleTail, rate: rate}
+}
+
+// AsyncOptions configures AsyncHandler.
+type AsyncOptions struct {
+	// BufferSize is the capacity of the ring buffer. Zero defaults to 1024.
+	BufferSize int
+
+	// Workers is the number of goroutines draining the buffer into the
+	// inner handler. Zero defaults to 1.
+	Workers int
+
+	// Policy controls what happens when BufferSize is reached. The zero
+	// value is BlockOnFull.
+	Policy Policy
+
+	// CloseTimeout bounds how long Close waits for the buffer to drain
+	// before writing the remainder synchronously. Zero means wait forever.
+	CloseTimeout time.Duration
+}
+
+// AsyncStats reports AsyncHandler's counters since it was created.
+type AsyncStats struct {
+	Enqueued    uint64
+	Dropped     uint64
+	WriteErrors uint64
+	QueueDepth  uint64
+}
+
+// AsyncStatsHandler is a Handler that also reports AsyncHandler's internal
+// counters.
+type AsyncStatsHandler interface {
+	Handler
+	HandlerCloser
+	Stats() AsyncStats
+}
+
+// asyncHandler decouples log producers from a slow inner Handler by
+// buffering Records on a channel drained by a fixed pool of workers.
+type asyncHandler struct {
+	inner  Handler
+	policy Policy
+	opts   AsyncOptions
+
+	queue chan Record
+
+	enqueued    atomic.Uint64
+	dropped     atomic.Uint64
+	writeErrors atomic.Uint64
+
+	wg     sync.WaitGroup
+	closed atomic.Bool
+
+	// closeMu pairs with closed/queue so that Close can't close ah.queue
+	// while a Handle call is between its closed check and its send on
+	// ah.queue: Handle holds the read side for that whole window, and
+	// Close takes the write side before flipping closed and closing the
+	// channel, so the two can never interleave.
+	closeMu sync.RWMutex
+}
+
+// AsyncHandler wraps inner so that Handle returns as soon as the record is
+// queued, leaving the actual write to a pool of background workers. opts.Policy
+// decides what happens once the queue is full.
+func AsyncHandler(inner Handler, opts AsyncOptions) Handler {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	ah := &asyncHandler{
+		inner:  inner,
+		policy: opts.Policy,
+		opts:   opts,
+	}
+	ah.queue = make(chan Record, opts.BufferSize)
+
+	ah.wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go ah.worker()
+	}
+	return ah
+}
+
+// worker drains the queue into the inner handler until it is closed.
+func (ah *asyncHandler) worker() {
+	defer ah.wg.Done()
+	for record := range ah.queue {
+		if err := ah.inner.Handle(record); err != nil {
+			ah.writeErrors.Add(1)
+		}
+	}
+}
+
+// Handle deep-copies record's slices and enqueues it according to opts.Policy.
+func (ah *asyncHandler) Handle(record Record) error {
+	ah.closeMu.RLock()
+	defer ah.closeMu.RUnlock()
+
+	if ah.closed.Load() {
+		return ah.inner.Handle(record)
+	}
+
+	record = copyRecord(record)
+	ah.enqueued.Add(1)
+
+	switch ah.policy.kind {
+	case policyBlock:
+		ah.queue <- record
+	case policyDropNewest:
+		select {
+		case ah.queue <- record:
+		default:
+			ah.dropped.Add(1)
+		}
+	case policyDropOldest:
+		ah.enqueueEvicting(record)
+	case policySampleTail:
+		select {
+		case ah.queue <- record:
+		default:
+			if sampleAllows(ah.policy.rate) {
+				ah.enqueueEvicting(record)
+			} else {
+				ah.dropped.Add(1)
+			}
+		}
+	}
+	return nil
+}
+
+// enqueueEvicting pushes record onto the queue, dropping the single oldest
+// buffered record first if the queue is full.
+func (ah *asyncHandler) enqueueEvicting(record Record) {
+	for {
+		select {
+		case ah.queue <- record:
+			return
+		default:
+		}
+		select {
+		case <-ah.queue:
+			ah.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// sampleAllows reports whether, at the given rate (0 to 1), an overflowing
+// record should still be admitted. It keeps a running fractional
+// accumulator (rather than integer-dividing 1/rate, which rounds to 1 for
+// any rate above 0.5 and would admit everything) so the long-run admission
+// ratio converges on rate regardless of its value.
+func sampleAllows(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	if rate >= 1 {
+		return true
+	}
+	for {
+		old := sampleAccumulator.Load()
+		acc := math.Float64frombits(old) + rate
+		admit := acc >= 1
+		if admit {
+			acc--
+		}
+		if sampleAccumulator.CompareAndSwap(old, math.Float64bits(acc)) {
+			return admit
+		}
+	}
+}
+
+// sampleAccumulator drives SampleTail's deterministic admission ratio,
+// stored as the bit pattern of a float64 so it can be updated atomically.
+var sampleAccumulator atomic.Uint64
+
+// copyRecord deep-copies the Pairs and ContextList slices so the queued
+// Record is immune to mutation or reuse by the logger that produced it.
+func copyRecord(record Record) Record {
+	if len(record.Pairs) > 0 {
+		pairs := make([]interface{}, len(record.Pairs))
+		copy(pairs, record.Pairs)
+		record.Pairs = pairs
+	}
+	if len(record.ContextList) > 0 {
+		ctx := make([]interface{}, len(record.ContextList))
+		copy(ctx, record.ContextList)
+		record.ContextList = ctx
+	}
+	return record
+}
+
+// Stats returns a snapshot of the handler's counters.
+func (ah *asyncHandler) Stats() AsyncStats {
+	return AsyncStats{
+		Enqueued:    ah.enqueued.Load(),
+		Dropped:     ah.dropped.Load(),
+		WriteErrors: ah.writeErrors.Load(),
+		QueueDepth:  uint64(len(ah.queue)),
+	}
+}
+
+// Close stops accepting new background work, waits up to opts.CloseTimeout
+// for the queue to drain, then writes any remainder synchronously before
+// closing the inner handler if it implements HandlerCloser.
+func (ah *asyncHandler) Close() {
+	ah.closeMu.Lock()
+	ah.closed.Store(true)
+	close(ah.queue)
+	ah.closeMu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		ah.wg.Wait()
+		close(drained)
+	}()
+
+	if ah.opts.CloseTimeout > 0 {
+		select {
+		case <-drained:
+		case <-time.After(ah.opts.CloseTimeout):
+			// Workers are still draining ah.queue concurrently; take
+			// whatever is left without blocking so each record is still
+			// written exactly once, just synchronously from here on.
+			for record := range ah.queue {
+				if err := ah.inner.Handle(record); err != nil {
+					ah.writeErrors.Add(1)
+				}
+			}
+			// The range above only returns once ah.queue is both closed
+			// and empty, but a worker can still be blocked inside
+			// ah.inner.Handle for an in-flight record it had already
+			// received. Wait for every worker to actually return before
+			// closing the inner handler out from under it.
+			<-drained
+		}
+	} else {
+		<-drained
+	}
+
+	if closer, ok := ah.inner.(HandlerCloser); ok {
+		closer.Close()
+	}
+}
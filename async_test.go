@@ -0,0 +1,161 @@
+package ligno
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingHandler blocks every Handle call until release is closed, so
+// tests can force the async handler's queue to fill up. started fires once
+// per call, right before blocking, so a test can wait for a call to be
+// in-flight instead of racing the worker goroutine.
+type blockingHandler struct {
+	mu      sync.Mutex
+	handled []Record
+	release chan struct{}
+	started chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{release: make(chan struct{}), started: make(chan struct{}, 16)}
+}
+
+func (h *blockingHandler) Handle(record Record) error {
+	h.started <- struct{}{}
+	<-h.release
+	h.mu.Lock()
+	h.handled = append(h.handled, record)
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *blockingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.handled)
+}
+
+func TestAsyncHandlerDropNewest(t *testing.T) {
+	inner := newBlockingHandler()
+	defer close(inner.release)
+
+	h := AsyncHandler(inner, AsyncOptions{BufferSize: 1, Workers: 1, Policy: DropNewest})
+	stats := h.(AsyncStatsHandler)
+
+	// Wait for the lone worker to pick up the first record and block on
+	// it, so the size-1 buffer is deterministically empty before the
+	// second Handle call fills it and the third has nowhere to go.
+	if err := h.Handle(Record{Message: "x"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	<-inner.started
+	for i := 0; i < 2; i++ {
+		if err := h.Handle(Record{Message: "x"}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+	}
+
+	got := stats.Stats()
+	if got.Dropped != 1 {
+		t.Errorf("Dropped = %d, want 1", got.Dropped)
+	}
+	if got.Enqueued != 3 {
+		t.Errorf("Enqueued = %d, want 3", got.Enqueued)
+	}
+}
+
+func TestAsyncHandlerDropOldest(t *testing.T) {
+	inner := newBlockingHandler()
+	defer close(inner.release)
+
+	h := AsyncHandler(inner, AsyncOptions{BufferSize: 1, Workers: 1, Policy: DropOldest})
+	stats := h.(AsyncStatsHandler)
+
+	if err := h.Handle(Record{Message: "x"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	<-inner.started
+	for i := 0; i < 2; i++ {
+		if err := h.Handle(Record{Message: "x"}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+	}
+
+	got := stats.Stats()
+	if got.Dropped != 1 {
+		t.Errorf("Dropped = %d, want 1", got.Dropped)
+	}
+	if got.Enqueued != 3 {
+		t.Errorf("Enqueued = %d, want 3", got.Enqueued)
+	}
+}
+
+func TestAsyncHandlerCloseWaitsForInFlightWork(t *testing.T) {
+	inner := newBlockingHandler()
+	h := AsyncHandler(inner, AsyncOptions{BufferSize: 4, Workers: 1, CloseTimeout: 20 * time.Millisecond})
+
+	if err := h.Handle(Record{Message: "slow"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	<-inner.started
+
+	// Close's CloseTimeout fires while the worker is still blocked inside
+	// inner.Handle; Close must wait for that in-flight call to return
+	// before returning itself, rather than racing it.
+	done := make(chan struct{})
+	go func() {
+		h.(HandlerCloser).Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Close returned while a worker was still blocked in inner.Handle")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(inner.release)
+	<-done
+
+	if inner.count() != 1 {
+		t.Errorf("inner handled %d records, want 1", inner.count())
+	}
+}
+
+// discardHandler accepts every record without blocking, for stress tests
+// that only care whether concurrent calls panic.
+type discardHandler struct{}
+
+func (discardHandler) Handle(Record) error { return nil }
+
+func TestAsyncHandlerHandleDoesNotRaceWithClose(t *testing.T) {
+	// Handle checks ah.closed and then sends on ah.queue; Close flips
+	// closed and closes ah.queue. Run many producers against a handler
+	// that's closed mid-flight so a missing guard between those two steps
+	// shows up as a "send on closed channel" panic under -race.
+	for i := 0; i < 200; i++ {
+		h := AsyncHandler(discardHandler{}, AsyncOptions{BufferSize: 1, Workers: 2})
+
+		var wg sync.WaitGroup
+		wg.Add(9)
+		for p := 0; p < 8; p++ {
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("Handle panicked: %v", r)
+					}
+				}()
+				for j := 0; j < 20; j++ {
+					h.Handle(Record{Message: "x"})
+				}
+			}()
+		}
+		go func() {
+			defer wg.Done()
+			h.(HandlerCloser).Close()
+		}()
+		wg.Wait()
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"unicode"
 	"time"
 
@@ -18,6 +20,114 @@ const (
 	errorKey = "PARSE_ERROR"
 )
 
+// TermTimeFormat is a compact timestamp layout, e.g. "07-25|15:04:05.000",
+// meant to be passed as TerminalFormatOptions.TimeFormat.
+const TermTimeFormat = "01-02|15:04:05.000"
+
+// termMsgJust is the minimum width the message field is justified to so that
+// key=value pairs line up into columns across records.
+const termMsgJust = 40
+
+// fieldWidths records, per key, the widest rendered value ever seen so that
+// successive lines can be right-padded into aligned columns.
+var (
+	fieldWidthsMu sync.RWMutex
+	fieldWidths   = make(map[string]int)
+)
+
+// printOrigins toggles whether ThemedTerminalFormat appends a "file:line"
+// origin to each record. It is stored as an atomic so PrintOrigins can be
+// called safely from any goroutine while formatters are running.
+var printOrigins atomic.Uint32
+
+// PrintOrigins enables or disables appending the call site ("file:line") to
+// every record produced by ThemedTerminalFormat.
+func PrintOrigins(enabled bool) {
+	if enabled {
+		printOrigins.Store(1)
+	} else {
+		printOrigins.Store(0)
+	}
+}
+
+// locationTrims holds the path prefixes stripped from an origin before it is
+// printed, set through LocationTrims.
+var (
+	locationTrimsMu sync.RWMutex
+	locationTrims   []string
+)
+
+// LocationTrims sets the list of path prefixes trimmed from the front of a
+// "file:line" origin before it is printed.
+func LocationTrims(trims []string) {
+	locationTrimsMu.Lock()
+	defer locationTrimsMu.Unlock()
+	locationTrims = trims
+}
+
+// originWidthMu/originWidth track the widest origin rendered so far, the
+// same running-max approach as fieldWidths, so that origins line up too.
+var (
+	originWidthMu sync.RWMutex
+	originWidth   int
+)
+
+// fieldWidth returns the padding width to use for key, updating the running
+// maximum if width is larger than anything seen for key before.
+func fieldWidth(key string, width int) int {
+	fieldWidthsMu.RLock()
+	max := fieldWidths[key]
+	fieldWidthsMu.RUnlock()
+	if width <= max {
+		return max
+	}
+	fieldWidthsMu.Lock()
+	if width > fieldWidths[key] {
+		fieldWidths[key] = width
+	}
+	fieldWidthsMu.Unlock()
+	return width
+}
+
+// trimOrigin strips any configured LocationTrims prefix from origin and pads
+// the result to the widest origin rendered so far.
+func trimOrigin(origin string) string {
+	locationTrimsMu.RLock()
+	trims := locationTrims
+	locationTrimsMu.RUnlock()
+	for _, trim := range trims {
+		if strings.HasPrefix(origin, trim) {
+			origin = strings.TrimPrefix(origin, trim)
+			break
+		}
+	}
+
+	originWidthMu.RLock()
+	max := originWidth
+	originWidthMu.RUnlock()
+	if len(origin) > max {
+		originWidthMu.Lock()
+		if len(origin) > originWidth {
+			originWidth = len(origin)
+		}
+		max = originWidth
+		originWidthMu.Unlock()
+	}
+	if pad := max - len(origin); pad > 0 {
+		origin += strings.Repeat(" ", pad)
+	}
+	return origin
+}
+
+// TerminalFormatOptions configures ThemedTerminalFormatWithOptions.
+type TerminalFormatOptions struct {
+	// TimeFormat is the layout used to render record.Time before the level.
+	// Leave empty to omit the timestamp, matching ThemedTerminalFormat's
+	// historical behavior; set it to TermTimeFormat for the compact
+	// "01-02|15:04:05.000" layout.
+	TimeFormat string
+}
+
 // Formatter is interface for converting log record to string representation.
 type Formatter interface {
 	Format(record Record) []byte
@@ -68,12 +178,22 @@ func TerminalFormat() Formatter {
 // easy reading in terminal, but that are a bit richer then SimpleFormat (this
 // one includes context keys)
 func ThemedTerminalFormat(theme Theme) Formatter {
+	return ThemedTerminalFormatWithOptions(theme, TerminalFormatOptions{})
+}
+
+// ThemedTerminalFormatWithOptions is ThemedTerminalFormat with control over
+// the timestamp layout via TerminalFormatOptions. Use PrintOrigins and
+// LocationTrims to additionally append the call site to every record.
+func ThemedTerminalFormatWithOptions(theme Theme, opts TerminalFormatOptions) Formatter {
 	return FormatterFunc(func(record Record) []byte {
-		//time := record.Time.Format(DefaultTimeFormat)
 		buff := buffPool.Get()
 		defer buffPool.Put(buff)
-		//buff.WriteString(theme.Time(time))
-		//buff.WriteRune(' ')
+
+		if opts.TimeFormat != "" {
+			buff.WriteString(theme.Time(record.Time.Format(opts.TimeFormat)))
+			buff.WriteRune(' ')
+		}
+
 		levelColor := theme.ForLevel(record.Level)
 		levelName := record.Level.String()
 		buff.WriteString(levelColor(levelName))
@@ -82,6 +202,9 @@ func ThemedTerminalFormat(theme Theme) Formatter {
 		buff.WriteRune(' ')
 
 		buff.WriteString(record.Message)
+		if justify := termMsgJust - len(record.Message); justify > 0 {
+			buff.Write(bytes.Repeat([]byte(" "), justify))
+		}
 
 		record.Pairs = append(record.ContextList, record.Pairs...)
 		record.Pairs = append([]interface{}{
@@ -105,8 +228,12 @@ func ThemedTerminalFormat(theme Theme) Formatter {
 			}
 			buff.WriteRune('=')
 			buff.WriteRune('"')
-			buff.WriteString(fmt.Sprintf("%+v", record.Pairs[i+1]))
+			value := fmt.Sprintf("%+v", record.Pairs[i+1])
+			buff.WriteString(value)
 			buff.WriteRune('"')
+			if pad := fieldWidth(k, len(value)) - len(value); pad > 0 {
+				buff.Write(bytes.Repeat([]byte(" "), pad))
+			}
 			if i < len(record.Pairs)-2 {
 				buff.WriteRune(' ')
 			}
@@ -114,6 +241,12 @@ func ThemedTerminalFormat(theme Theme) Formatter {
 		if len(record.Pairs) > 0 {
 			buff.WriteRune(']')
 		}
+
+		if printOrigins.Load() != 0 && record.File != "" && record.Line > 0 {
+			buff.WriteRune(' ')
+			buff.WriteString(trimOrigin(fmt.Sprintf("%s:%d", record.File, record.Line)))
+		}
+
 		buff.WriteRune('\n')
 		return buff.Bytes()
 	})
@@ -175,117 +308,8 @@ func JSONFormat(pretty bool) Formatter {
 	})
 }
 
+// LogFmtFormat is LogFmtFormatWithOptions with default float precision and
+// time format.
 func LogFmtFormat() Formatter {
-	return FormatterFunc(func(record Record) []byte {
-		record.Pairs = append(record.ContextList, record.Pairs...)
-
-		// set default info
-		record.Pairs = append([]interface{}{
-			"ts", record.Time,
-			"lvl", record.Level,
-			"msg", record.Message},
-			record.Pairs...)
-
-		if record.Line > 0 {
-			record.Pairs = append(record.Pairs, []interface{}{
-				"file", record.File,
-				"line", record.Line,
-			}...)
-		}
-
-		// encoding them according to logfmt
-		buf := &bytes.Buffer{}
-		for i := 0; i < len(record.Pairs); i += 2 {
-			if i != 0 {
-				buf.WriteByte(' ')
-			}
-
-			k, ok := record.Pairs[i].(string)
-			v := formatLogfmtValue(record.Pairs[i+1])
-			if !ok {
-				k, v = errorKey, formatLogfmtValue(k)
-			}
-
-			buf.WriteString(k)
-			buf.WriteByte('=')
-			buf.WriteString(v)
-		}
-
-		buf.WriteByte('\n')
-		return buf.Bytes()
-	})
-}
-
-// formatValue formats a value for serialization
-func formatLogfmtValue(value interface{}) string {
-	if value == nil {
-		return "nil"
-	}
-
-	if t, ok := value.(time.Time); ok {
-		// Performance optimization: No need for escaping since the provided
-		// timeFormat doesn't have any escape characters, and escaping is
-		// expensive.
-		return t.Format(DefaultTimeFormat)
-	}
-	//value = formatShared(value)
-	switch v := value.(type) {
-	case bool:
-		return strconv.FormatBool(v)
-	case float32:
-		return strconv.FormatFloat(float64(v), floatFormat, 3, 64)
-	case float64:
-		return strconv.FormatFloat(v, floatFormat, 3, 64)
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		return fmt.Sprintf("%d", value)
-	case string:
-		return escapeString(v)
-	default:
-		return escapeString(fmt.Sprintf("%+v", value))
-	}
-}
-
-func escapeString(s string) string {
-	needsQuotes := false
-	needsEscape := false
-	for _, r := range s {
-		if r <= ' ' || r == '=' || r == '"' {
-			needsQuotes = true
-		}
-		if r == '\\' || r == '"' || r == '\n' || r == '\r' || r == '\t' {
-			needsEscape = true
-		}
-	}
-	if needsEscape == false && needsQuotes == false {
-		return s
-	}
-
-	e := buffPool.Get()
-
-	e.WriteByte('"')
-	for _, r := range s {
-		switch r {
-		case '\\', '"':
-			e.WriteByte('\\')
-			e.WriteByte(byte(r))
-		case '\n':
-			e.WriteString("\\n")
-		case '\r':
-			e.WriteString("\\r")
-		case '\t':
-			e.WriteString("\\t")
-		default:
-			e.WriteRune(r)
-		}
-	}
-	e.WriteByte('"')
-	var ret string
-	if needsQuotes {
-		ret = e.String()
-	} else {
-		ret = string(e.Bytes()[1 : e.Len()-1])
-	}
-	e.Reset()
-	buffPool.Put(e)
-	return ret
+	return LogFmtFormatWithOptions(LogFmtFormatOptions{})
 }
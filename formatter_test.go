@@ -0,0 +1,58 @@
+package ligno
+
+import "testing"
+
+func TestFieldWidthTracksRunningMax(t *testing.T) {
+	// Use a key unique to this test so it doesn't interact with fieldWidths
+	// entries left behind by other tests or formatter calls.
+	const key = "test_field_width_key"
+	defer delete(fieldWidths, key)
+
+	if got := fieldWidth(key, 3); got != 3 {
+		t.Fatalf("fieldWidth(%d) = %d, want 3", 3, got)
+	}
+	if got := fieldWidth(key, 7); got != 7 {
+		t.Fatalf("fieldWidth(%d) = %d, want 7 (new max)", 7, got)
+	}
+	if got := fieldWidth(key, 2); got != 7 {
+		t.Fatalf("fieldWidth(%d) = %d, want 7 (previous max retained)", 2, got)
+	}
+}
+
+func TestTrimOriginAppliesLocationTrimsAndPadsToRunningMax(t *testing.T) {
+	origTrims := locationTrims
+	origWidth := originWidth
+	defer func() {
+		locationTrims = origTrims
+		originWidth = origWidth
+	}()
+
+	LocationTrims([]string{"/src/"})
+	originWidth = 0
+
+	got := trimOrigin("/src/pkg/file.go:10")
+	if want := "pkg/file.go:10"; got != want {
+		t.Errorf("trimOrigin = %q, want %q", got, want)
+	}
+
+	// A shorter origin seen afterwards should be padded out to the widest
+	// one rendered so far.
+	got = trimOrigin("/src/a.go:1")
+	if want := "a.go:1        "; got != want {
+		t.Errorf("trimOrigin (padded) = %q, want %q", got, want)
+	}
+}
+
+func TestPrintOriginsToggle(t *testing.T) {
+	defer PrintOrigins(false)
+
+	PrintOrigins(true)
+	if printOrigins.Load() == 0 {
+		t.Error("PrintOrigins(true) did not set printOrigins")
+	}
+
+	PrintOrigins(false)
+	if printOrigins.Load() != 0 {
+		t.Error("PrintOrigins(false) did not clear printOrigins")
+	}
+}
@@ -0,0 +1,152 @@
+//go:build linux
+
+package ligno
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// journaldSocket is the well-known path of systemd-journald's native
+// datagram socket.
+const journaldSocket = "/run/systemd/journal/socket"
+
+// journaldHandler speaks the sd_journal native protocol directly over a
+// unix datagram socket, bypassing syslog entirely.
+type journaldHandler struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// JournaldHandler connects to the local systemd-journald socket and returns
+// a Handler that writes each Record using the sd_journal native protocol,
+// reconnecting with exponential backoff on write failure.
+func JournaldHandler() (Handler, error) {
+	jh := &journaldHandler{}
+	if err := jh.connectLocked(); err != nil {
+		return nil, err
+	}
+	return jh, nil
+}
+
+// connectLocked dials a fresh connection to journaldSocket. Caller must hold
+// jh.mu.
+func (jh *journaldHandler) connectLocked() error {
+	conn, err := net.Dial("unixgram", journaldSocket)
+	if err != nil {
+		return err
+	}
+	if jh.conn != nil {
+		jh.conn.Close()
+	}
+	jh.conn = conn
+	return nil
+}
+
+// Handle encodes record as a sd_journal native-protocol datagram and sends
+// it, reconnecting with exponential backoff if the send fails.
+func (jh *journaldHandler) Handle(record Record) error {
+	payload := encodeJournalEntry(record)
+
+	jh.mu.Lock()
+	defer jh.mu.Unlock()
+
+	backoff := 50 * time.Millisecond
+	var err error
+	for attempt := 0; attempt < 4; attempt++ {
+		if jh.conn != nil {
+			if _, err = jh.conn.Write(payload); err == nil {
+				return nil
+			}
+		}
+		if rerr := jh.connectLocked(); rerr != nil {
+			err = rerr
+		}
+		if attempt < 3 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+// Close closes the underlying journald socket.
+func (jh *journaldHandler) Close() {
+	jh.mu.Lock()
+	defer jh.mu.Unlock()
+	if jh.conn != nil {
+		jh.conn.Close()
+		jh.conn = nil
+	}
+}
+
+// encodeJournalEntry renders record as the sd_journal native protocol:
+// newline-separated "FIELD=value" lines, or for values containing a
+// newline, "FIELD\n" followed by a little-endian uint64 length and the raw
+// value bytes. Entries large enough to need the memfd/SCM_RIGHTS transport
+// aren't handled here; callers keep individual field values small.
+func encodeJournalEntry(record Record) []byte {
+	var buf bytes.Buffer
+
+	writeField(&buf, "MESSAGE", record.Message)
+	writeField(&buf, "PRIORITY", strconv.Itoa(severityFor(record.Level)))
+
+	if record.File != "" {
+		writeField(&buf, "CODE_FILE", record.File)
+	}
+	if record.Line > 0 {
+		writeField(&buf, "CODE_LINE", strconv.Itoa(record.Line))
+	}
+
+	pairs := append(append([]interface{}{}, record.ContextList...), record.Pairs...)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			key = errorKey
+		}
+		writeField(&buf, journalFieldName(key), fmt.Sprintf("%+v", pairs[i+1]))
+	}
+
+	return buf.Bytes()
+}
+
+// journalFieldName upper-cases key and replaces any character that isn't a
+// letter, digit or underscore, the field name rules the journal enforces.
+func journalFieldName(key string) string {
+	key = strings.ToUpper(key)
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// writeField appends a single FIELD=value entry to buf in the sd_journal
+// native protocol, using the binary-safe form when value contains a
+// newline.
+func writeField(buf *bytes.Buffer, field, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(field)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteString(field)
+	buf.WriteByte('\n')
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(value)))
+	buf.Write(length[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package ligno
+
+import "errors"
+
+// JournaldHandler is unavailable outside Linux, where the sd_journal native
+// protocol doesn't exist.
+func JournaldHandler() (Handler, error) {
+	return nil, errors.New("ligno: JournaldHandler is only supported on linux")
+}
@@ -0,0 +1,167 @@
+package ligno
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+// LogFmtFormatOptions configures LogFmtFormatWithOptions.
+type LogFmtFormatOptions struct {
+	// FloatPrecision is the number of digits after the decimal point used
+	// when rendering float32/float64 values. Zero means 3, matching the
+	// formatter's historical behavior.
+	FloatPrecision int
+
+	// TimeFormat is the layout used to render time.Time values. Empty means
+	// DefaultTimeFormat.
+	TimeFormat string
+}
+
+// LogFmtFormatWithOptions returns a Formatter that encodes records as
+// logfmt, using github.com/go-logfmt/logfmt for the wire encoding. Values
+// are unwrapped through reflection before encoding so that pointers and
+// interfaces holding a time.Time, error, fmt.Stringer or
+// encoding.TextMarshaler are rendered the same way their concrete type
+// would be.
+func LogFmtFormatWithOptions(opts LogFmtFormatOptions) Formatter {
+	if opts.FloatPrecision == 0 {
+		opts.FloatPrecision = 3
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = DefaultTimeFormat
+	}
+
+	return FormatterFunc(func(record Record) []byte {
+		record.Pairs = append(record.ContextList, record.Pairs...)
+
+		record.Pairs = append([]interface{}{
+			"ts", record.Time,
+			"lvl", record.Level,
+			"msg", record.Message},
+			record.Pairs...)
+
+		if record.Line > 0 {
+			record.Pairs = append(record.Pairs, []interface{}{
+				"file", record.File,
+				"line", record.Line,
+			}...)
+		}
+
+		buf := &bytes.Buffer{}
+		enc := logfmt.NewEncoder(buf)
+		for i := 0; i < len(record.Pairs); i += 2 {
+			k, ok := record.Pairs[i].(string)
+			v := record.Pairs[i+1]
+			if !ok || !validLogfmtKey(k) {
+				v = fmt.Sprintf("%v=%v", k, v)
+				k = errorKey
+			}
+
+			if err := enc.EncodeKeyval(k, logfmtValue(v, opts)); err != nil {
+				enc.EncodeKeyval(errorKey, fmt.Sprintf("%s=%+v (%s)", k, v, err))
+			}
+		}
+		enc.EndRecord()
+		return buf.Bytes()
+	})
+}
+
+// validLogfmtKey reports whether k can be used as a logfmt key unescaped:
+// non-empty, valid UTF-8, and free of spaces, control characters, '=' and
+// '"'.
+func validLogfmtKey(k string) bool {
+	if k == "" || !utf8.ValidString(k) {
+		return false
+	}
+	for _, r := range k {
+		if r <= ' ' || r == '=' || r == '"' {
+			return false
+		}
+	}
+	return true
+}
+
+// isNilPointer reports whether v is a nil pointer wrapped in a non-nil
+// interface, e.g. `var e *MyErr; var err error = e`. Calling a value-receiver
+// method (Error/String/MarshalText) through such a value panics with "value
+// method ... called using nil pointer", so callers must check this first.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// logfmtValue special-cases the types logfmt can't render sensibly on its
+// own; everything else is passed through to the logfmt encoder unchanged.
+//
+// The error/Stringer/TextMarshaler/time.Time checks run against the
+// original value first, because those interfaces are overwhelmingly
+// implemented on pointer receivers (errors.New, fmt.Errorf, ...) and
+// reflect-unwrapping to the pointee before the type switch would strip
+// those methods. A value that matches none of them is then unwrapped through
+// reflection and, if that removed a pointer or interface, run through the
+// same cases again before the struct/slice/map fallback below.
+func logfmtValue(value interface{}, opts LogFmtFormatOptions) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(opts.TimeFormat)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.Format(opts.TimeFormat)
+	case float32:
+		return strconv.FormatFloat(float64(v), floatFormat, opts.FloatPrecision, 32)
+	case float64:
+		return strconv.FormatFloat(v, floatFormat, opts.FloatPrecision, 64)
+	case []byte:
+		return v
+	case error:
+		if isNilPointer(v) {
+			return nil
+		}
+		return v.Error()
+	case fmt.Stringer:
+		if isNilPointer(v) {
+			return nil
+		}
+		return v.String()
+	case encoding.TextMarshaler:
+		if isNilPointer(v) {
+			return nil
+		}
+		text, err := v.MarshalText()
+		if err != nil {
+			return fmt.Sprintf("%+v", value)
+		}
+		return string(text)
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && rv.CanInterface() && rv.Type() != reflect.TypeOf(value) {
+		return logfmtValue(rv.Interface(), opts)
+	}
+
+	// logfmt only understands nil, bool, numbers, strings, []byte and
+	// error/Stringer/TextMarshaler; everything else (structs, slices,
+	// maps, arrays) would otherwise hit ErrUnsupportedValueType, so
+	// render it the same way the old hand-rolled encoder did.
+	switch rv.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%+v", value)
+	default:
+		return value
+	}
+}
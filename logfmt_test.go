@@ -0,0 +1,121 @@
+package ligno
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logfmt/logfmt"
+)
+
+// decodeLogfmt runs line through logfmt.NewDecoder and returns it as a
+// key/value map, failing the test if it isn't valid logfmt.
+func decodeLogfmt(t *testing.T, line []byte) map[string]string {
+	t.Helper()
+	dec := logfmt.NewDecoder(bytes.NewReader(line))
+	got := make(map[string]string)
+	for dec.ScanRecord() {
+		for dec.ScanKeyval() {
+			got[string(dec.Key())] = string(dec.Value())
+		}
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatalf("logfmt.NewDecoder failed to parse output: %v\noutput: %s", err, line)
+	}
+	return got
+}
+
+type stringerValue struct{ name string }
+
+func (s stringerValue) String() string { return "stringer:" + s.name }
+
+// valueReceiverError has a value-receiver Error method, the shape that
+// panics with "value method ... called using nil pointer" if a nil *T is
+// invoked without a guard, since calling it requires dereferencing T.
+type valueReceiverError struct{ msg string }
+
+func (e valueReceiverError) Error() string { return e.msg }
+
+func TestLogFmtFormatRoundTrip(t *testing.T) {
+	record := Record{
+		Time:    time.Date(2026, 7, 25, 12, 0, 0, 0, time.UTC),
+		Level:   LevelInfo,
+		Message: "hello world",
+		Pairs: []interface{}{
+			"str", "plain",
+			"quoted", "needs quoting",
+			"err", errors.New("boom"),
+			"stringer", stringerValue{name: "x"},
+			"bytes", []byte("raw"),
+			"nested", struct{ A, B int }{A: 1, B: 2},
+			"slice", []int{1, 2, 3},
+			"float", 1.23456,
+		},
+	}
+
+	out := LogFmtFormat().Format(record)
+	got := decodeLogfmt(t, out)
+
+	want := map[string]string{
+		"msg":      "hello world",
+		"str":      "plain",
+		"quoted":   "needs quoting",
+		"err":      "boom",
+		"stringer": "stringer:x",
+		"bytes":    "raw",
+		"float":    "1.235",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("field %q = %q, want %q (full record: %v)", k, got[k], v, got)
+		}
+	}
+	if got["nested"] == "" || got["slice"] == "" {
+		t.Errorf("expected non-empty rendering for struct/slice fields, got %v", got)
+	}
+}
+
+func TestLogFmtFormatNilTypedErrorAndStringerDoNotPanic(t *testing.T) {
+	var nilErr *valueReceiverError
+	var nilStringer *stringerValue
+
+	record := Record{
+		Time:    time.Now(),
+		Level:   LevelInfo,
+		Message: "nil interface values",
+		Pairs: []interface{}{
+			"err", error(nilErr),
+			"stringer", fmt.Stringer(nilStringer),
+		},
+	}
+
+	out := LogFmtFormat().Format(record)
+	got := decodeLogfmt(t, out)
+
+	if got["err"] != "null" {
+		t.Errorf("err = %q, want %q for a nil typed error", got["err"], "null")
+	}
+	if got["stringer"] != "null" {
+		t.Errorf("stringer = %q, want %q for a nil typed Stringer", got["stringer"], "null")
+	}
+}
+
+func TestLogFmtFormatInvalidKeyUsesErrorKey(t *testing.T) {
+	record := Record{
+		Time:    time.Now(),
+		Level:   LevelInfo,
+		Message: "bad key",
+		Pairs: []interface{}{
+			"has space", "value",
+		},
+	}
+
+	out := LogFmtFormat().Format(record)
+	got := decodeLogfmt(t, out)
+
+	if _, ok := got[errorKey]; !ok {
+		t.Errorf("expected %s key for invalid field name, got %v", errorKey, got)
+	}
+}
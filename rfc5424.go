@@ -0,0 +1,131 @@
+package ligno
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Facility is a syslog facility code as defined by RFC 5424 section 6.2.1.
+type Facility int
+
+// Standard syslog facilities.
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	_
+	_
+	_
+	_
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// syslogSeverity maps a ligno Level onto an RFC 5424 severity (0 Emergency
+// through 7 Debug).
+var syslogSeverity = map[Level]int{
+	LevelCrit:  2,
+	LevelError: 3,
+	LevelWarn:  4,
+	LevelInfo:  6,
+	LevelDebug: 7,
+}
+
+// severityFor returns the RFC 5424 severity for level, defaulting to
+// Informational for levels not present in syslogSeverity.
+func severityFor(level Level) int {
+	if sev, ok := syslogSeverity[level]; ok {
+		return sev
+	}
+	return 6
+}
+
+// RFC5424Format returns a Formatter that renders each Record as an RFC 5424
+// syslog message:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP PROCID MSGID [SD-ID key="value" ...] MSG
+//
+// facility combines with the record's Level to produce PRI. Record.Pairs and
+// Record.ContextList become a single "ligno" structured-data element.
+func RFC5424Format(app, hostname string, facility Facility) Formatter {
+	procID := strconv.Itoa(os.Getpid())
+
+	return FormatterFunc(func(record Record) []byte {
+		buff := buffPool.Get()
+		defer buffPool.Put(buff)
+
+		pri := int(facility)*8 + severityFor(record.Level)
+		fmt.Fprintf(buff, "<%d>1 %s %s %s %s - ", pri,
+			record.Time.Format("2006-01-02T15:04:05.000Z07:00"),
+			nilsafe(hostname), nilsafe(app), procID)
+
+		pairs := append(append([]interface{}{}, record.ContextList...), record.Pairs...)
+		if len(pairs) == 0 {
+			buff.WriteString("-")
+		} else {
+			buff.WriteString("[ligno")
+			for i := 0; i+1 < len(pairs); i += 2 {
+				key, ok := pairs[i].(string)
+				if !ok {
+					key = errorKey
+				}
+				buff.WriteRune(' ')
+				buff.WriteString(key)
+				buff.WriteString(`="`)
+				buff.WriteString(escapeSDValue(fmt.Sprintf("%+v", pairs[i+1])))
+				buff.WriteRune('"')
+			}
+			buff.WriteString("]")
+		}
+
+		buff.WriteRune(' ')
+		buff.WriteString(record.Message)
+		buff.WriteRune('\n')
+		return buff.Bytes()
+	})
+}
+
+// nilsafe returns "-" for an empty string, the RFC 5424 placeholder for an
+// absent field.
+func nilsafe(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// escapeSDValue escapes the three characters RFC 5424 forbids unescaped
+// inside a structured-data PARAM-VALUE: ']', '"' and '\\'.
+func escapeSDValue(s string) string {
+	if strings.IndexFunc(s, func(r rune) bool {
+		return r == ']' || r == '"' || r == '\\'
+	}) < 0 {
+		return s
+	}
+	var buf bytes.Buffer
+	for _, r := range s {
+		if r == ']' || r == '"' || r == '\\' {
+			buf.WriteRune('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
@@ -0,0 +1,302 @@
+package ligno
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions configures RotatingFileHandler.
+type RotateOptions struct {
+	// MaxSizeBytes rotates the current file once it would grow past this
+	// size. Zero disables size-based rotation.
+	MaxSizeBytes int64
+
+	// MaxAgeDuration rotates the current file once it has been open for
+	// this long. Its backups get the same full-timestamp names as size-based
+	// ones (e.g. "app-2006-01-02T15-04-05.000.log"); they are not bucketed
+	// by day. Zero disables age-based rotation.
+	MaxAgeDuration time.Duration
+
+	// MaxBackups is the number of rotated backups kept around; older ones
+	// are pruned after each rotation. Zero keeps every backup.
+	MaxBackups int
+
+	// Compress gzips a backup in the background right after it is rotated.
+	Compress bool
+
+	// LocalTime uses the local timezone for backup timestamps instead of
+	// UTC.
+	LocalTime bool
+}
+
+// rotatingFileHandler writes formatted records to path, rotating it to a
+// timestamped backup once it crosses MaxSizeBytes or MaxAgeDuration.
+type rotatingFileHandler struct {
+	path      string
+	formatter Formatter
+	opts      RotateOptions
+
+	mu       sync.Mutex
+	f        *os.File
+	size     int64
+	openedAt time.Time
+
+	// pruneMu serializes compress+prune passes across rotations, kept
+	// separate from mu so that housekeeping for one rotation never blocks
+	// the write path of the next.
+	pruneMu sync.Mutex
+
+	wg sync.WaitGroup
+}
+
+// RotatingFileHandler writes formatted records to path, rotating the file to
+// a timestamped backup once MaxSizeBytes or MaxAgeDuration is crossed and
+// pruning/compressing old backups according to opts. Writes are serialized
+// with a mutex so the handler is safe under concurrent Handle calls.
+func RotatingFileHandler(path string, formatter Formatter, opts RotateOptions) Handler {
+	return &rotatingFileHandler{
+		path:      path,
+		formatter: formatter,
+		opts:      opts,
+	}
+}
+
+// now returns the time used for age-based rotation and backup names,
+// honoring opts.LocalTime.
+func (rh *rotatingFileHandler) now() time.Time {
+	if rh.opts.LocalTime {
+		return time.Now()
+	}
+	return time.Now().UTC()
+}
+
+// Handle writes the formatted record to the current file, rotating first if
+// the write would cross a configured threshold.
+func (rh *rotatingFileHandler) Handle(record Record) error {
+	formatted := rh.formatter.Format(record)
+
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	if err := rh.ensureOpenLocked(); err != nil {
+		return err
+	}
+	if rh.shouldRotateLocked(int64(len(formatted))) {
+		if err := rh.rotateLocked(); err != nil {
+			return err
+		}
+		if err := rh.ensureOpenLocked(); err != nil {
+			return err
+		}
+	}
+
+	n, err := rh.f.Write(formatted)
+	rh.size += int64(n)
+	return err
+}
+
+// ensureOpenLocked opens the current file if it isn't already. Caller must
+// hold rh.mu.
+func (rh *rotatingFileHandler) ensureOpenLocked() error {
+	if rh.f != nil {
+		return nil
+	}
+	f, err := os.OpenFile(rh.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	rh.f = f
+	rh.size = info.Size()
+	rh.openedAt = rh.now()
+	return nil
+}
+
+// shouldRotateLocked reports whether writing nextWrite more bytes, or the
+// age of the current file, crosses a configured threshold.
+func (rh *rotatingFileHandler) shouldRotateLocked(nextWrite int64) bool {
+	if rh.opts.MaxSizeBytes > 0 && rh.size+nextWrite > rh.opts.MaxSizeBytes {
+		return true
+	}
+	if rh.opts.MaxAgeDuration > 0 && rh.now().Sub(rh.openedAt) >= rh.opts.MaxAgeDuration {
+		return true
+	}
+	return false
+}
+
+// rotateLocked closes the current file, renames it to a timestamped backup,
+// and kicks off background compression and pruning. Caller must hold rh.mu.
+func (rh *rotatingFileHandler) rotateLocked() error {
+	if rh.f == nil {
+		return nil
+	}
+	if err := rh.f.Close(); err != nil {
+		return err
+	}
+	rh.f = nil
+
+	backup := rh.backupName()
+	if err := os.Rename(rh.path, backup); err != nil {
+		return err
+	}
+
+	rh.wg.Add(1)
+	go rh.finishRotation(backup)
+	return nil
+}
+
+// finishRotation compresses backup (if enabled) and then prunes old backups,
+// both off the write path so rotateLocked never blocks Handle on I/O that
+// doesn't need rh.mu held.
+func (rh *rotatingFileHandler) finishRotation(backup string) {
+	defer rh.wg.Done()
+	rh.pruneMu.Lock()
+	defer rh.pruneMu.Unlock()
+	if rh.opts.Compress {
+		rh.compress(backup)
+	}
+	rh.pruneBackups()
+}
+
+// backupName builds the timestamped backup path for the file currently at
+// rh.path, e.g. "app-2006-01-02T15-04-05.000.log".
+func (rh *rotatingFileHandler) backupName() string {
+	dir := filepath.Dir(rh.path)
+	ext := filepath.Ext(rh.path)
+	base := strings.TrimSuffix(filepath.Base(rh.path), ext)
+	stamp := rh.now().Format("2006-01-02T15-04-05.000")
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", base, stamp, ext))
+}
+
+// compress gzips backup in place, removing the uncompressed copy on
+// success. Errors are silently dropped, same as the rest of the rotation
+// housekeeping, since a failed compression must not take down logging.
+// Caller must hold rh.pruneMu, so that no pruneBackups pass can see backup
+// and its eventual ".gz" sibling until this returns.
+func (rh *rotatingFileHandler) compress(backup string) {
+	src, err := os.Open(backup)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(backup+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return
+	}
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		dst.Close()
+		return
+	}
+	if err := gw.Close(); err != nil {
+		dst.Close()
+		return
+	}
+	if err := dst.Close(); err != nil {
+		return
+	}
+	os.Remove(backup)
+}
+
+// pruneBackups removes the oldest rotated backups beyond opts.MaxBackups.
+// Call this once a backup's compression (if any) has finished, never while
+// one is still in flight: a backup mid-compression exists on disk as both
+// its raw file and a partially-written ".gz" sibling, and counting them as
+// two distinct backups would prune deeper than MaxBackups intends and risk
+// deleting the ".gz" out from under its own writer. Caller must hold
+// rh.pruneMu.
+func (rh *rotatingFileHandler) pruneBackups() {
+	if rh.opts.MaxBackups <= 0 {
+		return
+	}
+
+	dir := filepath.Dir(rh.path)
+	ext := filepath.Ext(rh.path)
+	base := strings.TrimSuffix(filepath.Base(rh.path), ext)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	// Group files by logical backup name, so a backup that currently has
+	// both "app-<stamp>.log" and "app-<stamp>.log.gz" on disk counts once.
+	backups := make(map[string][]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, base+"-") {
+			continue
+		}
+		var logical string
+		switch {
+		case strings.HasSuffix(name, ext+".gz"):
+			logical = strings.TrimSuffix(name, ".gz")
+		case strings.HasSuffix(name, ext):
+			logical = name
+		default:
+			continue
+		}
+		backups[logical] = append(backups[logical], filepath.Join(dir, name))
+	}
+	if len(backups) <= rh.opts.MaxBackups {
+		return
+	}
+
+	logicalNames := make([]string, 0, len(backups))
+	for logical := range backups {
+		logicalNames = append(logicalNames, logical)
+	}
+	sort.Strings(logicalNames)
+
+	for _, logical := range logicalNames[:len(logicalNames)-rh.opts.MaxBackups] {
+		for _, path := range backups[logical] {
+			os.Remove(path)
+		}
+	}
+}
+
+// Reopen closes the current file so the next Handle call reopens path from
+// scratch. Wire it up to a SIGHUP handler to support logrotate-style
+// external rotation:
+//
+//	sighup := make(chan os.Signal, 1)
+//	signal.Notify(sighup, syscall.SIGHUP)
+//	go func() {
+//		for range sighup {
+//			handler.Reopen()
+//		}
+//	}()
+func (rh *rotatingFileHandler) Reopen() error {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+	if rh.f == nil {
+		return nil
+	}
+	err := rh.f.Close()
+	rh.f = nil
+	return err
+}
+
+// Close flushes and closes the current file and waits for any in-flight
+// background compression to finish.
+func (rh *rotatingFileHandler) Close() {
+	rh.mu.Lock()
+	if rh.f != nil {
+		rh.f.Close()
+		rh.f = nil
+	}
+	rh.mu.Unlock()
+	rh.wg.Wait()
+}
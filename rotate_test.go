@@ -0,0 +1,131 @@
+package ligno
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countMatching returns the names in dir whose name contains substr.
+func countMatching(t *testing.T, dir, substr string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if strings.Contains(e.Name(), substr) {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+func TestRotatingFileHandlerRotatesOnSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	handler := RotatingFileHandler(path, FormatterFunc(func(Record) []byte {
+		return []byte("0123456789\n")
+	}), RotateOptions{MaxSizeBytes: 25})
+
+	for i := 0; i < 5; i++ {
+		if err := handler.Handle(Record{Message: "x"}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+	}
+	handler.(HandlerCloser).Close()
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected current log file to exist: %v", err)
+	}
+	backups := countMatching(t, dir, "app-")
+	if len(backups) == 0 {
+		t.Fatalf("expected at least one rotated backup in %s, got none", dir)
+	}
+}
+
+func TestRotatingFileHandlerPrunesBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	handler := RotatingFileHandler(path, FormatterFunc(func(Record) []byte {
+		return []byte("0123456789\n")
+	}), RotateOptions{MaxSizeBytes: 5, MaxBackups: 2})
+
+	for i := 0; i < 20; i++ {
+		if err := handler.Handle(Record{Message: "x"}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	handler.(HandlerCloser).Close()
+
+	backups := countMatching(t, dir, "app-")
+	if len(backups) > 2 {
+		t.Errorf("expected at most 2 pruned backups, got %d: %v", len(backups), backups)
+	}
+}
+
+func TestRotatingFileHandlerPrunesBackupsWithCompression(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	handler := RotatingFileHandler(path, FormatterFunc(func(Record) []byte {
+		return []byte("0123456789\n")
+	}), RotateOptions{MaxSizeBytes: 5, MaxBackups: 3, Compress: true})
+
+	for i := 0; i < 4; i++ {
+		if err := handler.Handle(Record{Message: "x"}); err != nil {
+			t.Fatalf("Handle: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	handler.(HandlerCloser).Close()
+
+	// Each rotated backup should survive as exactly one file on disk (its
+	// raw form or its compressed ".gz", never both transiently counted as
+	// two), so retaining MaxBackups=3 logical backups must leave exactly 3
+	// files behind, not fewer.
+	backups := countMatching(t, dir, "app-")
+	if len(backups) != 3 {
+		t.Errorf("expected exactly 3 retained backups, got %d: %v", len(backups), backups)
+	}
+	for _, name := range backups {
+		if !strings.HasSuffix(name, ".gz") {
+			t.Errorf("expected backup %q to be compressed", name)
+		}
+	}
+}
+
+func TestRotatingFileHandlerReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	handler := RotatingFileHandler(path, FormatterFunc(func(Record) []byte {
+		return []byte("line\n")
+	}), RotateOptions{})
+	rh := handler.(interface{ Reopen() error })
+
+	if err := handler.Handle(Record{Message: "first"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if err := rh.Reopen(); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+	if err := handler.Handle(Record{Message: "second"}); err != nil {
+		t.Fatalf("Handle after Reopen: %v", err)
+	}
+	handler.(HandlerCloser).Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if got := string(data); got != "line\nline\n" {
+		t.Errorf("file contents = %q, want %q", got, "line\nline\n")
+	}
+}
@@ -0,0 +1,172 @@
+package ligno
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+// slogLevelTrans maps ligno's verbosity levels onto slog's numeric levels so
+// that a FilterLevelHandler placed upstream or downstream of the bridge keeps
+// filtering the same records.
+var slogLevelTrans = map[Level]slog.Level{
+	LevelDebug: slog.LevelDebug,
+	LevelInfo:  slog.LevelInfo,
+	LevelWarn:  slog.LevelWarn,
+	LevelError: slog.LevelError,
+	LevelCrit:  slog.LevelError + 4,
+}
+
+// levelFromSlog maps a slog.Level back onto the closest ligno Level, rounding
+// down to the nearest defined severity.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	case level < slog.LevelError+4:
+		return LevelError
+	default:
+		return LevelCrit
+	}
+}
+
+// slogAttr is an attribute captured by WithAttrs, with its key already
+// joined to the group path that was active at the time WithAttrs was
+// called — groups opened afterwards by WithGroup must not retroactively
+// apply to it.
+type slogAttr struct {
+	key   string
+	value interface{}
+}
+
+// slogHandler adapts a ligno Handler into a slog.Handler.
+type slogHandler struct {
+	handler Handler
+	attrs   []slogAttr
+	groups  []string
+}
+
+// SlogHandler adapts handler into a slog.Handler so that it can be passed to
+// slog.New and used anywhere the standard library logger is expected.
+func SlogHandler(handler Handler) slog.Handler {
+	return &slogHandler{handler: handler}
+}
+
+// Enabled reports that every record is enabled; filtering is left to
+// FilterLevelHandler further down the chain, same as with ligno's own
+// Logger.
+func (sh *slogHandler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// Handle converts rec to a ligno Record and passes it to the wrapped Handler.
+func (sh *slogHandler) Handle(ctx context.Context, rec slog.Record) error {
+	pairs := make([]interface{}, 0, 2*rec.NumAttrs()+2*len(sh.attrs))
+	for _, attr := range sh.attrs {
+		pairs = append(pairs, attr.key, attr.value)
+	}
+	rec.Attrs(func(attr slog.Attr) bool {
+		pairs = append(pairs, sh.attrKey(attr.Key), attr.Value.Resolve().Any())
+		return true
+	})
+
+	record := Record{
+		Time:    rec.Time,
+		Level:   levelFromSlog(rec.Level),
+		Message: rec.Message,
+		Pairs:   pairs,
+	}
+
+	if rec.PC != 0 {
+		record.File, record.Line = sourceFromPC(rec.PC)
+	}
+
+	return sh.handler.Handle(record)
+}
+
+// attrKey prefixes key with the dot-joined group path, mirroring how slog's
+// own handlers namespace grouped attributes.
+func (sh *slogHandler) attrKey(key string) string {
+	if len(sh.groups) == 0 {
+		return key
+	}
+	prefixed := key
+	for i := len(sh.groups) - 1; i >= 0; i-- {
+		prefixed = sh.groups[i] + "." + prefixed
+	}
+	return prefixed
+}
+
+// sourceFromPC resolves a program counter to the file:line pair ligno's
+// Record uses, the same information Logger captures via runtime.Caller.
+func sourceFromPC(pc uintptr) (file string, line int) {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	return frame.File, frame.Line
+}
+
+// WithAttrs returns a new slog.Handler whose pairs are appended to every
+// subsequent record, same as slog's own handlers. Each attr's key is joined
+// to the group path active right now, so a later WithGroup doesn't
+// retroactively re-namespace it.
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	newAttrs := make([]slogAttr, 0, len(sh.attrs)+len(attrs))
+	newAttrs = append(newAttrs, sh.attrs...)
+	for _, attr := range attrs {
+		newAttrs = append(newAttrs, slogAttr{
+			key:   sh.attrKey(attr.Key),
+			value: attr.Value.Resolve().Any(),
+		})
+	}
+	return &slogHandler{handler: sh.handler, attrs: newAttrs, groups: sh.groups}
+}
+
+// WithGroup returns a new slog.Handler that namespaces subsequent attributes
+// under name.
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	newGroups := make([]string, 0, len(sh.groups)+1)
+	newGroups = append(newGroups, sh.groups...)
+	newGroups = append(newGroups, name)
+	return &slogHandler{handler: sh.handler, attrs: sh.attrs, groups: newGroups}
+}
+
+// fromSlogHandler adapts a slog.Handler into a ligno Handler so it can be
+// combined with native ligno handlers via CombiningHandler.
+type fromSlogHandler struct {
+	handler slog.Handler
+}
+
+// FromSlogHandler adapts handler into a ligno Handler, translating Record
+// fields onto the equivalent slog.Record/slog.Attr/slog.Source so the two
+// logging worlds can be mixed with CombiningHandler.
+func FromSlogHandler(handler slog.Handler) Handler {
+	return &fromSlogHandler{handler: handler}
+}
+
+// Handle converts record to a slog.Record and passes it to the wrapped
+// slog.Handler.
+func (fh *fromSlogHandler) Handle(record Record) error {
+	rec := slog.NewRecord(record.Time, slogLevelTrans[record.Level], record.Message, 0)
+
+	pairs := append(append([]interface{}{}, record.ContextList...), record.Pairs...)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			continue
+		}
+		rec.AddAttrs(slog.Any(key, pairs[i+1]))
+	}
+
+	if record.File != "" {
+		rec.AddAttrs(slog.Any("source", &slog.Source{
+			File: record.File,
+			Line: record.Line,
+		}))
+	}
+
+	return fh.handler.Handle(context.Background(), rec)
+}
@@ -0,0 +1,105 @@
+package ligno
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestLevelFromSlogBoundaries(t *testing.T) {
+	cases := []struct {
+		level slog.Level
+		want  Level
+	}{
+		{slog.LevelDebug, LevelDebug},
+		{slog.LevelInfo - 1, LevelDebug},
+		{slog.LevelInfo, LevelInfo},
+		{slog.LevelWarn - 1, LevelInfo},
+		{slog.LevelWarn, LevelWarn},
+		{slog.LevelError - 1, LevelWarn},
+		{slog.LevelError, LevelError},
+		{slog.LevelError + 3, LevelError},
+		{slog.LevelError + 4, LevelCrit},
+		{slog.LevelError + 10, LevelCrit},
+	}
+	for _, c := range cases {
+		if got := levelFromSlog(c.level); got != c.want {
+			t.Errorf("levelFromSlog(%v) = %v, want %v", c.level, got, c.want)
+		}
+	}
+}
+
+// recordingHandler stores every Record handed to it, for inspecting what
+// SlogHandler produced.
+type recordingHandler struct {
+	records []Record
+}
+
+func (rh *recordingHandler) Handle(record Record) error {
+	rh.records = append(rh.records, record)
+	return nil
+}
+
+func TestSlogHandlerWithGroupDoesNotRetroapplyToEarlierAttrs(t *testing.T) {
+	inner := &recordingHandler{}
+	h := SlogHandler(inner)
+
+	h = h.WithAttrs([]slog.Attr{slog.String("early", "v1")})
+	h = h.WithGroup("g")
+	h = h.WithAttrs([]slog.Attr{slog.String("late", "v2")})
+
+	rec := slog.NewRecord(time.Now(), slog.LevelInfo, "hi", 0)
+	if err := h.Handle(context.Background(), rec); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if len(inner.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(inner.records))
+	}
+	pairs := inner.records[0].Pairs
+	got := map[string]interface{}{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		got[pairs[i].(string)] = pairs[i+1]
+	}
+	if got["early"] != "v1" {
+		t.Errorf("early attr key = %v, want unprefixed %q, got pairs %v", got["early"], "v1", got)
+	}
+	if got["g.late"] != "v2" {
+		t.Errorf("late attr key = %v, want \"g.late\"=\"v2\", got pairs %v", got["g.late"], got)
+	}
+}
+
+// capturingSlogHandler is a minimal slog.Handler that remembers the last
+// record it was handed.
+type capturingSlogHandler struct {
+	last slog.Record
+}
+
+func (c *capturingSlogHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (c *capturingSlogHandler) Handle(_ context.Context, rec slog.Record) error {
+	c.last = rec
+	return nil
+}
+func (c *capturingSlogHandler) WithAttrs([]slog.Attr) slog.Handler { return c }
+func (c *capturingSlogHandler) WithGroup(string) slog.Handler      { return c }
+
+func TestFromSlogHandlerTranslatesLevel(t *testing.T) {
+	capture := &capturingSlogHandler{}
+	fh := FromSlogHandler(capture)
+
+	err := fh.Handle(Record{
+		Time:    time.Now(),
+		Level:   LevelError,
+		Message: "boom",
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if capture.last.Level != slog.LevelError {
+		t.Errorf("handled.Level = %v, want %v", capture.last.Level, slog.LevelError)
+	}
+	if capture.last.Message != "boom" {
+		t.Errorf("handled.Message = %q, want %q", capture.last.Message, "boom")
+	}
+}
@@ -0,0 +1,94 @@
+package ligno
+
+import (
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// syslogHandler writes RFC 5424 formatted records to a local or remote
+// syslog endpoint, reconnecting with exponential backoff on write failure.
+type syslogHandler struct {
+	network string
+	addr    string
+
+	formatter Formatter
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// SyslogHandler dials addr over network (e.g. "unix" for the local
+// /dev/log, or "udp"/"tcp" for a remote syslog collector) and returns a
+// Handler that writes each Record as an RFC 5424 message tagged with tag and
+// facility. Leaving both network and addr empty connects to the local
+// /dev/log socket.
+func SyslogHandler(network, addr, tag string, facility Facility) (Handler, error) {
+	if network == "" && addr == "" {
+		network, addr = "unix", "/dev/log"
+	}
+
+	hostname, _ := os.Hostname()
+	sh := &syslogHandler{
+		network:   network,
+		addr:      addr,
+		formatter: RFC5424Format(tag, hostname, facility),
+	}
+
+	if err := sh.connectLocked(); err != nil {
+		return nil, err
+	}
+	return sh, nil
+}
+
+// connectLocked dials a fresh connection, replacing sh.conn. Caller must
+// hold sh.mu.
+func (sh *syslogHandler) connectLocked() error {
+	conn, err := net.DialTimeout(sh.network, sh.addr, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	if sh.conn != nil {
+		sh.conn.Close()
+	}
+	sh.conn = conn
+	return nil
+}
+
+// Handle formats record and writes it to the syslog connection, reconnecting
+// with exponential backoff if the write fails.
+func (sh *syslogHandler) Handle(record Record) error {
+	formatted := sh.formatter.Format(record)
+
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	backoff := 50 * time.Millisecond
+	var err error
+	for attempt := 0; attempt < 4; attempt++ {
+		if sh.conn != nil {
+			if _, err = sh.conn.Write(formatted); err == nil {
+				return nil
+			}
+		}
+		if rerr := sh.connectLocked(); rerr != nil {
+			err = rerr
+		}
+		if attempt < 3 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
+
+// Close closes the underlying syslog connection.
+func (sh *syslogHandler) Close() {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.conn != nil {
+		sh.conn.Close()
+		sh.conn = nil
+	}
+}
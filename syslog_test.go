@@ -0,0 +1,109 @@
+package ligno
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSyslogHandlerWritesOverTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	h, err := SyslogHandler("tcp", ln.Addr().String(), "myapp", FacilityUser)
+	if err != nil {
+		t.Fatalf("SyslogHandler: %v", err)
+	}
+	defer h.(HandlerCloser).Close()
+
+	if err := h.Handle(Record{Time: time.Now(), Level: LevelInfo, Message: "hello"}); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	select {
+	case line := <-received:
+		if line == "" {
+			t.Error("expected a non-empty RFC 5424 message on the wire")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for syslog write")
+	}
+}
+
+func TestSyslogHandlerReconnectsAfterWriteFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan struct{}, 1)
+	resetNow := make(chan struct{})
+	secondReceived := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		accepted <- struct{}{}
+		<-resetNow
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			// Force an RST instead of a graceful FIN so the client's next
+			// write deterministically fails instead of racing the close.
+			tcp.SetLinger(0)
+		}
+		conn.Close()
+
+		conn, err = ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		secondReceived <- line
+	}()
+
+	h, err := SyslogHandler("tcp", ln.Addr().String(), "myapp", FacilityUser)
+	if err != nil {
+		t.Fatalf("SyslogHandler: %v", err)
+	}
+	defer h.(HandlerCloser).Close()
+
+	// Wait for the server to have accepted the connection SyslogHandler
+	// just established before resetting it, so the RST can't race the
+	// Dial call that created it.
+	<-accepted
+	close(resetNow)
+	time.Sleep(50 * time.Millisecond)
+
+	// The handler's connection has just been closed by the peer; Handle
+	// should transparently reconnect and retry rather than returning an
+	// error for the very next call.
+	if err := h.Handle(Record{Time: time.Now(), Level: LevelInfo, Message: "after reconnect"}); err != nil {
+		t.Fatalf("Handle after peer close: %v", err)
+	}
+
+	select {
+	case line := <-secondReceived:
+		if line == "" {
+			t.Error("expected the reconnected write to carry the message")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for handler to reconnect and write")
+	}
+}